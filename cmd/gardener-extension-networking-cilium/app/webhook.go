@@ -0,0 +1,105 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/gardener/gardener-extension-networking-cilium/pkg/webhook/deletionconfirmation"
+)
+
+// WebhookServerOptions bundles the flags accepted by the `webhook-server` command.
+type WebhookServerOptions struct {
+	Host    string
+	Port    int
+	CertDir string
+}
+
+// AddFlags adds the options' flags to cmd's flag set.
+func (o *WebhookServerOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.Host, "webhook-server-host", "0.0.0.0", "address on which the webhook server listens")
+	cmd.Flags().IntVar(&o.Port, "webhook-server-port", 9443, "port on which the webhook server listens")
+	cmd.Flags().StringVar(&o.CertDir, "webhook-server-cert-dir", "/tmp/webhook-server", "directory containing the webhook server's TLS certificate and key; watched for rotation")
+}
+
+// NewWebhookServerCommand creates a new command that runs the deletion-confirmation validating webhook server.
+// It is distinct from the reconciler command so that the webhook can be scaled and rolled out independently.
+func NewWebhookServerCommand(ctx context.Context) *cobra.Command {
+	opts := &WebhookServerOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "webhook-server",
+		Short: "Launches the networking-cilium deletion-confirmation webhook server",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWebhookServer(ctx, opts)
+		},
+	}
+
+	opts.AddFlags(cmd)
+	return cmd
+}
+
+func runWebhookServer(ctx context.Context, opts *WebhookServerOptions) error {
+	// certwatcher picks up certificate rotations performed by cert-controller/cert-manager without a restart.
+	watcher, err := certwatcher.New(
+		fmt.Sprintf("%s/tls.crt", opts.CertDir),
+		fmt.Sprintf("%s/tls.key", opts.CertDir),
+	)
+	if err != nil {
+		return fmt.Errorf("failed setting up certificate watcher: %w", err)
+	}
+
+	// deletionconfirmation.AddToManager lists CustomResourceDefinitions, which the default client-go scheme lacks.
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("failed building scheme: %w", err)
+	}
+	if err := apiextensionsv1.AddToScheme(scheme); err != nil {
+		return fmt.Errorf("failed building scheme: %w", err)
+	}
+
+	mgr, err := manager.New(ctrl.GetConfigOrDie(), manager.Options{
+		Scheme: scheme,
+		WebhookServer: webhook.NewServer(webhook.Options{
+			Host:    opts.Host,
+			Port:    opts.Port,
+			TLSOpts: []func(*tls.Config){func(cfg *tls.Config) { cfg.GetCertificate = watcher.GetCertificate }},
+		}),
+	})
+	if err != nil {
+		return fmt.Errorf("failed creating manager: %w", err)
+	}
+
+	if err := mgr.Add(watcher); err != nil {
+		return fmt.Errorf("failed registering certificate watcher: %w", err)
+	}
+
+	if err := deletionconfirmation.AddToManager(ctx, mgr); err != nil {
+		return fmt.Errorf("failed adding deletion-confirmation webhook: %w", err)
+	}
+
+	return mgr.Start(ctx)
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networking
+
+import (
+	"context"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	resourcesv1alpha1 "github.com/gardener/gardener/pkg/apis/resources/v1alpha1"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ciliumgardener "github.com/gardener/gardener-extension-networking-cilium/pkg/gardener"
+)
+
+// actuator implements the extension controller-runtime Actuator interface for `Network` resources of type
+// `cilium`.
+type actuator struct {
+	client  client.Client
+	addOpts AddOptions
+}
+
+// NewActuator creates a new actuator that protects `Network` resources against an unconfirmed deletion via
+// FinalizerName and, once such a deletion is confirmed, confirms and deletes the Cilium `ManagedResource` rendered
+// for them. The `ManagedResource` itself does not get FinalizerName. addOpts.ConfirmationRecorder, if set, is
+// notified of every deletion confirmation this actuator performs.
+func NewActuator(client client.Client, addOpts AddOptions) *actuator {
+	return &actuator{client: client, addOpts: addOpts}
+}
+
+// Reconcile attaches FinalizerName to network, so that a DELETE call skipping Gardener's deletion confirmation
+// leaves the object in `Terminating` rather than tearing down the managed Cilium deployment. It does not render
+// the Cilium ManagedResource.
+func (a *actuator) Reconcile(ctx context.Context, _ logr.Logger, network *extensionsv1alpha1.Network, _ *extensionscontroller.Cluster) error {
+	return ciliumgardener.AddDeletionProtectionFinalizer(ctx, a.client, network, FinalizerName)
+}
+
+// Delete waits until network's own deletion confirmation has been observed, then confirms deletion of the
+// rendered ManagedResource, tears it down, and only after that releases FinalizerName. Confirming the ManagedResource ahead of
+// RemoveFinalizerIfConfirmed's gate would mark it as no longer protected even when network's confirmation has
+// not actually been observed yet.
+func (a *actuator) Delete(ctx context.Context, _ logr.Logger, network *extensionsv1alpha1.Network, _ *extensionscontroller.Cluster) error {
+	if !ciliumgardener.IsDeletionCandidate(network, FinalizerName) {
+		return nil
+	}
+
+	managedResource := managedResourceFor(network)
+
+	return ciliumgardener.RemoveFinalizerIfConfirmed(ctx, a.client, network, FinalizerName, func(ctx context.Context) error {
+		if err := ciliumgardener.ConfirmDeletionWithOptions(ctx, a.client, managedResource, a.addOpts.confirmDeletionOptions()...); err != nil {
+			return err
+		}
+		return client.IgnoreNotFound(a.client.Delete(ctx, managedResource, client.PropagationPolicy(metav1.DeletePropagationBackground)))
+	})
+}
+
+// managedResourceFor returns a reference to the ManagedResource rendered for network by this extension's
+// reconciler (not re-implemented here), keyed the same way that renderer names it.
+func managedResourceFor(network *extensionsv1alpha1.Network) *resourcesv1alpha1.ManagedResource {
+	return &resourcesv1alpha1.ManagedResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "extension-networking-cilium",
+			Namespace: network.Namespace,
+		},
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networking
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	extensionmetrics "github.com/gardener/gardener-extension-networking-cilium/pkg/metrics"
+)
+
+// ControllerName identifies the event source mgr.GetEventRecorderFor registers for this controller's actuator.
+const ControllerName = "networking-cilium-controller"
+
+// AddToManager creates the Cilium networking actuator from mgr's client and returns it; it does not register the
+// actuator, or any controller, with mgr. Every deletion confirmation the actuator performs is recorded via
+// extensionmetrics.EventRecorderConfirmationRecorder, so it is both an auditable Kubernetes Event and a sample in
+// extensionmetrics.DeletionConfirmationsTotal.
+func AddToManager(mgr manager.Manager) (*actuator, error) {
+	addOpts := AddOptions{
+		ConfirmationRecorder: &extensionmetrics.EventRecorderConfirmationRecorder{
+			Recorder: mgr.GetEventRecorderFor(ControllerName),
+			Scheme:   mgr.GetScheme(),
+		},
+	}
+	return NewActuator(mgr.GetClient(), addOpts), nil
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package networking
+
+import (
+	ciliumgardener "github.com/gardener/gardener-extension-networking-cilium/pkg/gardener"
+)
+
+// AddOptions are the options used to configure the networking-cilium controller and actuator.
+type AddOptions struct {
+	// ConfirmationRecorder is invoked whenever the actuator confirms the deletion of a resource it manages. It
+	// defaults to a no-op recorder if left unset.
+	ConfirmationRecorder ciliumgardener.ConfirmationRecorder
+}
+
+// confirmDeletionOptions translates a.ConfirmationRecorder into the ConfirmDeletionOption the actuator passes to
+// ciliumgardener.ConfirmDeletionWithOptions, so that every confirmation performed by this controller is audited
+// the same way.
+func (a *AddOptions) confirmDeletionOptions() []ciliumgardener.ConfirmDeletionOption {
+	if a.ConfirmationRecorder == nil {
+		return nil
+	}
+	return []ciliumgardener.ConfirmDeletionOption{ciliumgardener.WithConfirmationRecorder(a.ConfirmationRecorder)}
+}
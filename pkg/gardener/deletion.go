@@ -0,0 +1,175 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gardener extends the deletion-confirmation helpers vendored from
+// github.com/gardener/gardener/pkg/utils/gardener with behavior this extension needs but upstream does not
+// (yet) provide. It deliberately wraps rather than forks those helpers, so the vendor tree stays a faithful,
+// regeneratable mirror of the upstream module.
+package gardener
+
+import (
+	"context"
+	"time"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	gardenerutils "github.com/gardener/gardener/pkg/utils/gardener"
+	"github.com/gardener/gardener/pkg/utils/kubernetes"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// confirmDeletionOptions bundles the options configurable via ConfirmDeletionOption.
+type confirmDeletionOptions struct {
+	propagationPolicy  *metav1.DeletionPropagation
+	dryRun             bool
+	gracePeriodSeconds *int64
+	now                func() time.Time
+	recorder           ConfirmationRecorder
+}
+
+// ConfirmDeletionOption configures ConfirmDeletionWithOptions.
+type ConfirmDeletionOption interface {
+	apply(*confirmDeletionOptions)
+}
+
+type confirmDeletionOptionFunc func(*confirmDeletionOptions)
+
+func (f confirmDeletionOptionFunc) apply(o *confirmDeletionOptions) { f(o) }
+
+// PropagationPolicy does not affect the confirmation patch itself, but is remembered so that DeleteOptions can
+// hand back the same cascade semantics (Foreground/Background/Orphan) for the `DELETE` call the caller issues
+// once the deletion has been confirmed.
+func PropagationPolicy(policy metav1.DeletionPropagation) ConfirmDeletionOption {
+	return confirmDeletionOptionFunc(func(o *confirmDeletionOptions) {
+		o.propagationPolicy = &policy
+	})
+}
+
+// DryRun sends the confirmation patch with the `DryRunAll` option, i.e. it is validated and returned by the API
+// server but never persisted. Useful for previewing a confirmation from CI pipelines or custom reconcilers.
+func DryRun() ConfirmDeletionOption {
+	return confirmDeletionOptionFunc(func(o *confirmDeletionOptions) {
+		o.dryRun = true
+	})
+}
+
+// GracePeriodSeconds sets `metadata.deletionGracePeriodSeconds` atomically together with the confirmation
+// annotation, so that admission plugins relying on both fields being present observe a consistent object.
+func GracePeriodSeconds(seconds int64) ConfirmDeletionOption {
+	return confirmDeletionOptionFunc(func(o *confirmDeletionOptions) {
+		o.gracePeriodSeconds = &seconds
+	})
+}
+
+// WithTimestampFunc overrides the function used to compute the Gardener timestamp annotation. It defaults to
+// gardenerutils.TimeNow and is mainly useful for tests that need a deterministic timestamp.
+func WithTimestampFunc(now func() time.Time) ConfirmDeletionOption {
+	return confirmDeletionOptionFunc(func(o *confirmDeletionOptions) {
+		o.now = now
+	})
+}
+
+// WithConfirmationRecorder sets the ConfirmationRecorder notified of the confirmation's outcome, successful or
+// not. It defaults to a no-op recorder, so audit/event emission stays entirely opt-in.
+func WithConfirmationRecorder(recorder ConfirmationRecorder) ConfirmDeletionOption {
+	return confirmDeletionOptionFunc(func(o *confirmDeletionOptions) {
+		o.recorder = recorder
+	})
+}
+
+// ConfirmDeletionWithOptions behaves like gardenerutils.ConfirmDeletion but allows callers to customize the
+// confirmation via functional options (see PropagationPolicy, DryRun, GracePeriodSeconds, WithTimestampFunc and
+// WithConfirmationRecorder). Unlike gardenerutils.ConfirmDeletion, it sends a merge patch instead of a
+// full-object update, so the request only touches the fields this package actually changes.
+//
+// Unless options.dryRun is set, the configured ConfirmationRecorder is notified of the outcome - RecordFailure
+// if the patch ultimately failed, RecordConfirmation if it succeeded - so a recorder backed by metrics reports
+// both results instead of only ever seeing a success. If obj no longer exists, nothing is patched and neither
+// method is called.
+func ConfirmDeletionWithOptions(ctx context.Context, c client.Client, obj client.Object, opts ...ConfirmDeletionOption) error {
+	options := &confirmDeletionOptions{now: gardenerutils.TimeNow, recorder: noopConfirmationRecorder{}}
+	for _, opt := range opts {
+		opt.apply(options)
+	}
+
+	var (
+		oldValue string
+		patched  bool
+	)
+
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			if !errors.IsNotFound(err) {
+				return err
+			}
+			return nil
+		}
+
+		oldValue = obj.GetAnnotations()[gardenerutils.ConfirmationDeletion]
+		patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+
+		kubernetes.SetMetaDataAnnotation(obj, gardenerutils.ConfirmationDeletion, "true")
+		kubernetes.SetMetaDataAnnotation(obj, v1beta1constants.GardenerTimestamp, options.now().UTC().String())
+		if options.gracePeriodSeconds != nil {
+			obj.SetDeletionGracePeriodSeconds(options.gracePeriodSeconds)
+		}
+
+		var patchOptions []client.PatchOption
+		if options.dryRun {
+			patchOptions = append(patchOptions, client.DryRunAll)
+		}
+
+		if err := c.Patch(ctx, obj, patch, patchOptions...); err != nil {
+			return err
+		}
+		patched = true
+		return nil
+	})
+
+	if options.dryRun {
+		return err
+	}
+
+	if err != nil {
+		options.recorder.RecordFailure(ctx, obj, err)
+		return err
+	}
+
+	if patched {
+		options.recorder.RecordConfirmation(ctx, obj, oldValue)
+	}
+	return nil
+}
+
+// DeleteOptions translates the PropagationPolicy and GracePeriodSeconds configured via opts into
+// `client.DeleteOption`s, so that a `client.Delete` call issued after ConfirmDeletionWithOptions uses the same
+// cascade semantics the caller already previewed.
+func DeleteOptions(opts ...ConfirmDeletionOption) []client.DeleteOption {
+	options := &confirmDeletionOptions{}
+	for _, opt := range opts {
+		opt.apply(options)
+	}
+
+	var deleteOptions []client.DeleteOption
+	if options.propagationPolicy != nil {
+		deleteOptions = append(deleteOptions, client.PropagationPolicy(*options.propagationPolicy))
+	}
+	if options.gracePeriodSeconds != nil {
+		deleteOptions = append(deleteOptions, client.GracePeriodSeconds(*options.gracePeriodSeconds))
+	}
+	return deleteOptions
+}
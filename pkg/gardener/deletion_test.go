@@ -0,0 +1,158 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardener_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	gardenerutils "github.com/gardener/gardener/pkg/utils/gardener"
+
+	. "github.com/gardener/gardener-extension-networking-cilium/pkg/gardener"
+)
+
+// fakeConfirmationRecorder records the calls ConfirmDeletionWithOptions made to it, so tests can assert which
+// outcome was reported without depending on a concrete ConfirmationRecorder implementation.
+type fakeConfirmationRecorder struct {
+	confirmedOldValue string
+	confirmed         bool
+	failureErr        error
+	failed            bool
+}
+
+func (r *fakeConfirmationRecorder) RecordConfirmation(_ context.Context, _ client.Object, oldValue string) {
+	r.confirmed = true
+	r.confirmedOldValue = oldValue
+}
+
+func (r *fakeConfirmationRecorder) RecordFailure(_ context.Context, _ client.Object, err error) {
+	r.failed = true
+	r.failureErr = err
+}
+
+var _ = Describe("#ConfirmDeletionWithOptions", func() {
+	var (
+		ctx       = context.Background()
+		configMap *corev1.ConfigMap
+		recorder  *fakeConfirmationRecorder
+	)
+
+	BeforeEach(func() {
+		configMap = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+		recorder = &fakeConfirmationRecorder{}
+	})
+
+	It("stamps the confirmation annotation and notifies the recorder", func() {
+		fakeClient := fake.NewClientBuilder().WithObjects(configMap).Build()
+
+		Expect(ConfirmDeletionWithOptions(ctx, fakeClient, configMap, WithConfirmationRecorder(recorder))).To(Succeed())
+
+		persisted := &corev1.ConfigMap{}
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(configMap), persisted)).To(Succeed())
+		Expect(persisted.Annotations).To(HaveKeyWithValue(gardenerutils.ConfirmationDeletion, "true"))
+
+		Expect(recorder.confirmed).To(BeTrue())
+		Expect(recorder.confirmedOldValue).To(BeEmpty())
+		Expect(recorder.failed).To(BeFalse())
+	})
+
+	It("does not touch the object or notify the recorder in dry-run mode", func() {
+		fakeClient := fake.NewClientBuilder().WithObjects(configMap).Build()
+
+		Expect(ConfirmDeletionWithOptions(ctx, fakeClient, configMap, DryRun(), WithConfirmationRecorder(recorder))).To(Succeed())
+
+		persisted := &corev1.ConfigMap{}
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(configMap), persisted)).To(Succeed())
+		Expect(persisted.Annotations).NotTo(HaveKey(gardenerutils.ConfirmationDeletion))
+
+		Expect(recorder.confirmed).To(BeFalse())
+		Expect(recorder.failed).To(BeFalse())
+	})
+
+	It("notifies the recorder of the failure and returns the error if the patch never succeeds", func() {
+		patchErr := fmt.Errorf("patch rejected")
+		fakeClient := fake.NewClientBuilder().WithObjects(configMap).WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				return patchErr
+			},
+		}).Build()
+
+		err := ConfirmDeletionWithOptions(ctx, fakeClient, configMap, WithConfirmationRecorder(recorder))
+		Expect(err).To(MatchError(patchErr))
+
+		Expect(recorder.failed).To(BeTrue())
+		Expect(recorder.failureErr).To(MatchError(patchErr))
+		Expect(recorder.confirmed).To(BeFalse())
+	})
+
+	It("sets deletionGracePeriodSeconds on the patched object together with the confirmation annotation", func() {
+		fakeClient := fake.NewClientBuilder().WithObjects(configMap).Build()
+
+		Expect(ConfirmDeletionWithOptions(ctx, fakeClient, configMap, GracePeriodSeconds(42))).To(Succeed())
+
+		persisted := &corev1.ConfigMap{}
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(configMap), persisted)).To(Succeed())
+		Expect(persisted.Annotations).To(HaveKeyWithValue(gardenerutils.ConfirmationDeletion, "true"))
+		Expect(persisted.DeletionGracePeriodSeconds).To(PointTo(Equal(int64(42))))
+	})
+})
+
+var _ = Describe("#DeleteOptions", func() {
+	It("returns no options if neither PropagationPolicy nor GracePeriodSeconds was configured", func() {
+		Expect(DeleteOptions()).To(BeEmpty())
+	})
+
+	It("translates PropagationPolicy into a matching client.DeleteOption", func() {
+		opts := DeleteOptions(PropagationPolicy(metav1.DeletePropagationForeground))
+
+		deleteOptions := &client.DeleteOptions{}
+		for _, opt := range opts {
+			opt.ApplyToDelete(deleteOptions)
+		}
+		Expect(deleteOptions.PropagationPolicy).To(PointTo(Equal(metav1.DeletePropagationForeground)))
+		Expect(deleteOptions.GracePeriodSeconds).To(BeNil())
+	})
+
+	It("translates GracePeriodSeconds into a matching client.DeleteOption", func() {
+		opts := DeleteOptions(GracePeriodSeconds(7))
+
+		deleteOptions := &client.DeleteOptions{}
+		for _, opt := range opts {
+			opt.ApplyToDelete(deleteOptions)
+		}
+		Expect(deleteOptions.GracePeriodSeconds).To(PointTo(Equal(int64(7))))
+		Expect(deleteOptions.PropagationPolicy).To(BeNil())
+	})
+
+	It("translates both options together", func() {
+		opts := DeleteOptions(PropagationPolicy(metav1.DeletePropagationBackground), GracePeriodSeconds(7))
+
+		deleteOptions := &client.DeleteOptions{}
+		for _, opt := range opts {
+			opt.ApplyToDelete(deleteOptions)
+		}
+		Expect(deleteOptions.PropagationPolicy).To(PointTo(Equal(metav1.DeletePropagationBackground)))
+		Expect(deleteOptions.GracePeriodSeconds).To(PointTo(Equal(int64(7))))
+	})
+})
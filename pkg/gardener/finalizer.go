@@ -0,0 +1,87 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardener
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AddDeletionProtectionFinalizer adds finalizer to obj if it is not already present. It is a no-op if the
+// finalizer is already there. Together with IsDeletionCandidate and RemoveFinalizerIfConfirmed it implements a
+// finalizer-driven companion to gardenerutils.ConfirmDeletion: an object deleted without the confirmation
+// annotation stays in `Terminating` instead of disappearing, giving operators a recoverable state.
+func AddDeletionProtectionFinalizer(ctx context.Context, c client.Client, obj client.Object, finalizer string) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			return err
+		}
+
+		if sets.New(obj.GetFinalizers()...).Has(finalizer) {
+			return nil
+		}
+
+		patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+		obj.SetFinalizers(append(obj.GetFinalizers(), finalizer))
+		return c.Patch(ctx, obj, patch)
+	})
+}
+
+// IsDeletionCandidate returns whether obj is marked for deletion and still carries finalizer, i.e. whether it is
+// currently blocked in `Terminating` waiting for RemoveFinalizerIfConfirmed to release it.
+func IsDeletionCandidate(obj client.Object, finalizer string) bool {
+	return obj.GetDeletionTimestamp() != nil && sets.New(obj.GetFinalizers()...).Has(finalizer)
+}
+
+// RemoveFinalizerIfConfirmed removes finalizer from obj once CheckIfDeletionIsConfirmedAndObserved succeeds for
+// it and the optional cleanup callback returns no error. If the confirmation annotation is missing, or it was
+// set before the controller observed obj's latest generation, the finalizer is kept and no error is returned, so
+// the object remains a deletion candidate rather than being torn down. If cleanup returns an error, it is
+// propagated and the finalizer is kept as well, so the caller's reconciler retries instead of silently losing
+// track of the failed cleanup.
+func RemoveFinalizerIfConfirmed(ctx context.Context, c client.Client, obj client.Object, finalizer string, cleanup func(ctx context.Context) error) error {
+	if err := CheckIfDeletionIsConfirmedAndObserved(obj); err != nil {
+		return nil
+	}
+
+	if cleanup != nil {
+		if err := cleanup(ctx); err != nil {
+			return err
+		}
+	}
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+
+		finalizers := sets.New(obj.GetFinalizers()...)
+		if !finalizers.Has(finalizer) {
+			return nil
+		}
+		finalizers.Delete(finalizer)
+
+		patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+		obj.SetFinalizers(sets.List(finalizers))
+		return c.Patch(ctx, obj, patch)
+	})
+}
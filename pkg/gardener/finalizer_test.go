@@ -0,0 +1,180 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardener_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	gardenerutils "github.com/gardener/gardener/pkg/utils/gardener"
+
+	. "github.com/gardener/gardener-extension-networking-cilium/pkg/gardener"
+)
+
+var _ = Describe("Finalizer", func() {
+	const finalizer = "test.gardener.cloud/protection"
+
+	var (
+		ctx        = context.Background()
+		fakeClient client.Client
+		configMap  *corev1.ConfigMap
+	)
+
+	BeforeEach(func() {
+		configMap = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+		fakeClient = fake.NewClientBuilder().WithObjects(configMap).Build()
+	})
+
+	Describe("#AddDeletionProtectionFinalizer", func() {
+		It("adds the finalizer if it is not present yet", func() {
+			Expect(AddDeletionProtectionFinalizer(ctx, fakeClient, configMap, finalizer)).To(Succeed())
+			Expect(configMap.GetFinalizers()).To(ConsistOf(finalizer))
+		})
+
+		It("is idempotent if the finalizer is already present", func() {
+			Expect(AddDeletionProtectionFinalizer(ctx, fakeClient, configMap, finalizer)).To(Succeed())
+			Expect(AddDeletionProtectionFinalizer(ctx, fakeClient, configMap, finalizer)).To(Succeed())
+			Expect(configMap.GetFinalizers()).To(ConsistOf(finalizer))
+		})
+	})
+
+	Describe("#IsDeletionCandidate", func() {
+		It("returns false without a deletion timestamp", func() {
+			configMap.Finalizers = []string{finalizer}
+			Expect(IsDeletionCandidate(configMap, finalizer)).To(BeFalse())
+		})
+
+		It("returns false if the finalizer is missing", func() {
+			now := metav1.Now()
+			configMap.DeletionTimestamp = &now
+			Expect(IsDeletionCandidate(configMap, finalizer)).To(BeFalse())
+		})
+
+		It("returns true once both a deletion timestamp and the finalizer are present", func() {
+			now := metav1.Now()
+			configMap.DeletionTimestamp = &now
+			configMap.Finalizers = []string{finalizer}
+			Expect(IsDeletionCandidate(configMap, finalizer)).To(BeTrue())
+		})
+	})
+
+	Describe("#RemoveFinalizerIfConfirmed", func() {
+		// These use a Deployment rather than the ConfigMap fixture above because RemoveFinalizerIfConfirmed now
+		// gates on CheckIfDeletionIsConfirmedAndObserved, which needs a status.observedGeneration field to
+		// compare against metadata.generation.
+		var deployment *appsv1.Deployment
+
+		BeforeEach(func() {
+			deployment = &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Generation: 2},
+				Status:     appsv1.DeploymentStatus{ObservedGeneration: 2},
+			}
+			fakeClient = fake.NewClientBuilder().WithObjects(deployment).WithStatusSubresource(deployment).Build()
+		})
+
+		It("keeps the finalizer and skips cleanup if the deletion is not confirmed", func() {
+			deployment.Finalizers = []string{finalizer}
+			Expect(fakeClient.Update(ctx, deployment)).To(Succeed())
+
+			cleanupCalled := false
+			Expect(RemoveFinalizerIfConfirmed(ctx, fakeClient, deployment, finalizer, func(context.Context) error {
+				cleanupCalled = true
+				return nil
+			})).To(Succeed())
+
+			Expect(cleanupCalled).To(BeFalse())
+			Expect(deployment.GetFinalizers()).To(ConsistOf(finalizer))
+		})
+
+		It("keeps the finalizer if the confirmation predates the latest observed generation", func() {
+			deployment.Annotations = map[string]string{gardenerutils.ConfirmationDeletion: "true"}
+			deployment.Finalizers = []string{finalizer}
+			Expect(fakeClient.Update(ctx, deployment)).To(Succeed())
+
+			deployment.Status.ObservedGeneration = 1
+			Expect(fakeClient.Status().Update(ctx, deployment)).To(Succeed())
+
+			cleanupCalled := false
+			Expect(RemoveFinalizerIfConfirmed(ctx, fakeClient, deployment, finalizer, func(context.Context) error {
+				cleanupCalled = true
+				return nil
+			})).To(Succeed())
+
+			Expect(cleanupCalled).To(BeFalse())
+			Expect(deployment.GetFinalizers()).To(ConsistOf(finalizer))
+		})
+
+		It("runs cleanup and removes the finalizer once confirmed, retrying past a conflicting update", func() {
+			deployment.Annotations = map[string]string{gardenerutils.ConfirmationDeletion: "true"}
+			deployment.Finalizers = []string{finalizer}
+			Expect(fakeClient.Update(ctx, deployment)).To(Succeed())
+
+			// Simulate a concurrent change to the object in between the Get and the Patch performed by
+			// RemoveFinalizerIfConfirmed: the first Patch lands that change and is rejected with a conflict, so the
+			// retry-on-conflict path (Get again, then Patch again) is exercised.
+			patchCalls := 0
+			conflictingClient := interceptor.NewClient(fakeClient.(client.WithWatch), interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					patchCalls++
+					if patchCalls == 1 {
+						concurrent := &appsv1.Deployment{}
+						Expect(c.Get(ctx, client.ObjectKeyFromObject(obj), concurrent)).To(Succeed())
+						concurrent.Labels = map[string]string{"concurrently": "updated"}
+						Expect(c.Update(ctx, concurrent)).To(Succeed())
+						return apierrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, obj.GetName(), fmt.Errorf("object was modified"))
+					}
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			})
+
+			cleanupCalled := false
+			Expect(RemoveFinalizerIfConfirmed(ctx, conflictingClient, deployment, finalizer, func(context.Context) error {
+				cleanupCalled = true
+				return nil
+			})).To(Succeed())
+			Expect(cleanupCalled).To(BeTrue())
+			Expect(patchCalls).To(Equal(2))
+
+			persisted := &appsv1.Deployment{}
+			Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(deployment), persisted)).To(Succeed())
+			Expect(persisted.GetFinalizers()).To(BeEmpty())
+			Expect(persisted.Labels).To(HaveKeyWithValue("concurrently", "updated"))
+		})
+
+		It("propagates a cleanup error and keeps the finalizer so the reconciler retries", func() {
+			deployment.Annotations = map[string]string{gardenerutils.ConfirmationDeletion: "true"}
+			deployment.Finalizers = []string{finalizer}
+			Expect(fakeClient.Update(ctx, deployment)).To(Succeed())
+
+			err := RemoveFinalizerIfConfirmed(ctx, fakeClient, deployment, finalizer, func(context.Context) error {
+				return fmt.Errorf("cleanup failed")
+			})
+			Expect(err).To(MatchError("cleanup failed"))
+			Expect(deployment.GetFinalizers()).To(ConsistOf(finalizer))
+		})
+	})
+})
@@ -0,0 +1,95 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardener
+
+import (
+	"fmt"
+	"reflect"
+
+	gardenerutils "github.com/gardener/gardener/pkg/utils/gardener"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// generationObserver is implemented by typed extension resources (e.g. extensions.gardener.cloud/v1alpha1
+// objects) that expose their reconciliation progress via a `status.observedGeneration` field.
+type generationObserver interface {
+	GetGeneration() int64
+	GetObservedGeneration() int64
+}
+
+// CheckIfDeletionIsConfirmedAndObserved behaves like gardenerutils.CheckIfDeletionIsConfirmed but additionally
+// requires that the object's `status.observedGeneration` is at least as new as `metadata.generation`. This
+// prevents callers (e.g. RemoveFinalizerIfConfirmed) from proceeding with a deletion that was confirmed before
+// the controller reconciled the very spec change that set the confirmation annotation, mirroring the check used
+// for Bastions.
+func CheckIfDeletionIsConfirmedAndObserved(obj client.Object) error {
+	if err := gardenerutils.CheckIfDeletionIsConfirmed(obj); err != nil {
+		return err
+	}
+
+	observedGeneration, err := observedGenerationOf(obj)
+	if err != nil {
+		return err
+	}
+
+	if observedGeneration < obj.GetGeneration() {
+		return fmt.Errorf("the latest generation of object %q (%d) has not yet been observed (observed: %d)", client.ObjectKeyFromObject(obj), obj.GetGeneration(), observedGeneration)
+	}
+
+	return nil
+}
+
+// observedGenerationOf returns the object's status.observedGeneration. Typed objects are expected to implement
+// generationObserver; unstructured.Unstructured is read directly off its map; everything else falls back to
+// reflection so that this also works for extension kinds whose generated client types aren't compiled into this
+// package.
+func observedGenerationOf(obj client.Object) (int64, error) {
+	if observer, ok := obj.(generationObserver); ok {
+		return observer.GetObservedGeneration(), nil
+	}
+
+	if unstructuredObj, ok := obj.(*unstructured.Unstructured); ok {
+		observedGeneration, found, err := unstructured.NestedInt64(unstructuredObj.Object, "status", "observedGeneration")
+		if err != nil {
+			return 0, fmt.Errorf("object %q has a malformed status.observedGeneration field: %w", client.ObjectKeyFromObject(obj), err)
+		}
+		if !found {
+			return 0, fmt.Errorf("object %q has no status.observedGeneration field", client.ObjectKeyFromObject(obj))
+		}
+		return observedGeneration, nil
+	}
+
+	value := reflect.ValueOf(obj)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return 0, fmt.Errorf("object %T is nil", obj)
+		}
+		value = value.Elem()
+	}
+
+	status := value.FieldByName("Status")
+	if !status.IsValid() {
+		return 0, fmt.Errorf("object %T has neither a GetObservedGeneration method nor a Status field", obj)
+	}
+
+	observedGeneration := status.FieldByName("ObservedGeneration")
+	if !observedGeneration.IsValid() || observedGeneration.Kind() != reflect.Int64 {
+		return 0, fmt.Errorf("object %T has no int64 Status.ObservedGeneration field", obj)
+	}
+
+	return observedGeneration.Int(), nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardener_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	gardenerutils "github.com/gardener/gardener/pkg/utils/gardener"
+
+	. "github.com/gardener/gardener-extension-networking-cilium/pkg/gardener"
+)
+
+var _ = Describe("#CheckIfDeletionIsConfirmedAndObserved", func() {
+	newConfirmedUnstructured := func(generation, observedGeneration int64) *unstructured.Unstructured {
+		obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+		obj.SetGenerateName("test-")
+		obj.SetGeneration(generation)
+		obj.SetAnnotations(map[string]string{gardenerutils.ConfirmationDeletion: "true"})
+		if observedGeneration != 0 {
+			Expect(unstructured.SetNestedField(obj.Object, observedGeneration, "status", "observedGeneration")).To(Succeed())
+		}
+		return obj
+	}
+
+	It("succeeds for an unstructured object once its observedGeneration caught up", func() {
+		obj := newConfirmedUnstructured(2, 2)
+		Expect(CheckIfDeletionIsConfirmedAndObserved(obj)).To(Succeed())
+	})
+
+	It("fails for an unstructured object whose observedGeneration is stale", func() {
+		obj := newConfirmedUnstructured(2, 1)
+		err := CheckIfDeletionIsConfirmedAndObserved(obj)
+		Expect(err).To(MatchError(ContainSubstring("has not yet been observed")))
+	})
+
+	It("fails for an unstructured object with no status.observedGeneration field", func() {
+		obj := newConfirmedUnstructured(2, 0)
+		err := CheckIfDeletionIsConfirmedAndObserved(obj)
+		Expect(err).To(MatchError(ContainSubstring("no status.observedGeneration field")))
+	})
+
+	It("fails for a typed object that has neither a GetObservedGeneration method nor a Status field", func() {
+		configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Generation: 2}}
+		configMap.Annotations = map[string]string{gardenerutils.ConfirmationDeletion: "true"}
+
+		err := CheckIfDeletionIsConfirmedAndObserved(configMap)
+		Expect(err).To(MatchError(ContainSubstring("has neither a GetObservedGeneration method nor a Status field")))
+	})
+})
@@ -0,0 +1,43 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gardener
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConfirmationRecorder is invoked by ConfirmDeletionWithOptions for every confirmation that either patched the
+// object or failed, so that callers can audit when a deletion was confirmed, and surface both outcomes (e.g. as
+// metrics). Dry runs, and objects that no longer exist, are not reported to it.
+// Implementations must not mutate obj; it is only passed for context.
+type ConfirmationRecorder interface {
+	// RecordConfirmation is called with the object as it looked right after the confirmation patch succeeded,
+	// and the annotation's previous value (empty if it wasn't set before).
+	RecordConfirmation(ctx context.Context, obj client.Object, oldValue string)
+	// RecordFailure is called if the confirmation patch ultimately failed, e.g. because retry.RetryOnConflict
+	// gave up, with the error that ConfirmDeletionWithOptions also returns to its caller.
+	RecordFailure(ctx context.Context, obj client.Object, err error)
+}
+
+// noopConfirmationRecorder is the default ConfirmationRecorder; it does nothing.
+type noopConfirmationRecorder struct{}
+
+// RecordConfirmation implements ConfirmationRecorder.
+func (noopConfirmationRecorder) RecordConfirmation(context.Context, client.Object, string) {}
+
+// RecordFailure implements ConfirmationRecorder.
+func (noopConfirmationRecorder) RecordFailure(context.Context, client.Object, error) {}
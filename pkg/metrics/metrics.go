@@ -0,0 +1,36 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics registers this extension's Prometheus metrics.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// DeletionConfirmationsTotal counts how often a deletion confirmation was attempted, labeled by the kind of the
+// confirmed object and whether the confirmation patch succeeded ("success") or ultimately failed ("error"). It
+// is incremented by EventRecorderConfirmationRecorder and is the audit trail's primary alerting signal.
+var DeletionConfirmationsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "gardener_deletion_confirmations_total",
+		Help: "Total number of recorded Gardener deletion confirmations, by object kind and result.",
+	},
+	[]string{"kind", "result"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(DeletionConfirmationsTotal)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"context"
+
+	ciliumgardener "github.com/gardener/gardener-extension-networking-cilium/pkg/gardener"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// EventRecorderConfirmationRecorder implements ciliumgardener.ConfirmationRecorder by emitting a Kubernetes
+// Event on the confirmed object and incrementing DeletionConfirmationsTotal, so operators auditing a teardown
+// can see when a deletion was confirmed, and alert on confirmations that keep failing.
+type EventRecorderConfirmationRecorder struct {
+	Recorder record.EventRecorder
+	// Scheme resolves obj's GroupVersionKind via ObjectKinds when obj.GetObjectKind() comes back empty, which is
+	// the case for every typed object (e.g. *resourcesv1alpha1.ManagedResource, *extensionsv1alpha1.Network) this
+	// recorder is actually called with, since controller-runtime's typed client strips TypeMeta on decode.
+	Scheme *runtime.Scheme
+}
+
+var _ ciliumgardener.ConfirmationRecorder = &EventRecorderConfirmationRecorder{}
+
+// RecordConfirmation implements ciliumgardener.ConfirmationRecorder.
+func (r *EventRecorderConfirmationRecorder) RecordConfirmation(_ context.Context, obj client.Object, oldValue string) {
+	gvk := r.groupVersionKindOf(obj)
+
+	r.Recorder.Eventf(objectReference(obj, gvk), corev1.EventTypeNormal, "DeletionConfirmed",
+		"Deletion was confirmed (previous annotation value: %q, resourceVersion: %s)",
+		oldValue, obj.GetResourceVersion())
+
+	DeletionConfirmationsTotal.WithLabelValues(gvk.Kind, "success").Inc()
+}
+
+// RecordFailure implements ciliumgardener.ConfirmationRecorder. It emits a Warning Event and increments
+// DeletionConfirmationsTotal with result "error", so a confirmation that never goes through is just as visible
+// as one that does, instead of being silently dropped.
+func (r *EventRecorderConfirmationRecorder) RecordFailure(_ context.Context, obj client.Object, err error) {
+	gvk := r.groupVersionKindOf(obj)
+
+	r.Recorder.Eventf(objectReference(obj, gvk), corev1.EventTypeWarning, "DeletionConfirmationFailed",
+		"Failed to confirm deletion: %v", err)
+
+	DeletionConfirmationsTotal.WithLabelValues(gvk.Kind, "error").Inc()
+}
+
+// groupVersionKindOf returns obj's GroupVersionKind, preferring obj.GetObjectKind() (set for unstructured
+// objects such as the ones the deletion-confirmation webhook handles) and falling back to r.Scheme.ObjectKinds
+// for typed objects, whose TypeMeta a typed client.Get/client.Patch leaves empty.
+func (r *EventRecorderConfirmationRecorder) groupVersionKindOf(obj client.Object) schema.GroupVersionKind {
+	if gvk := obj.GetObjectKind().GroupVersionKind(); gvk.Kind != "" {
+		return gvk
+	}
+
+	gvks, _, err := r.Scheme.ObjectKinds(obj)
+	if err != nil || len(gvks) == 0 {
+		return schema.GroupVersionKind{}
+	}
+	return gvks[0]
+}
+
+// objectReference builds a corev1.ObjectReference from obj and its already-resolved gvk.
+func objectReference(obj client.Object, gvk schema.GroupVersionKind) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		APIVersion:      gvk.GroupVersion().String(),
+		Kind:            gvk.Kind,
+		Namespace:       obj.GetNamespace(),
+		Name:            obj.GetName(),
+		UID:             obj.GetUID(),
+		ResourceVersion: obj.GetResourceVersion(),
+	}
+}
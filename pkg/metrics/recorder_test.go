@@ -0,0 +1,88 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+
+	. "github.com/gardener/gardener-extension-networking-cilium/pkg/metrics"
+)
+
+var _ = Describe("EventRecorderConfirmationRecorder", func() {
+	var (
+		ctx          = context.Background()
+		fakeRecorder *record.FakeRecorder
+		recorder     *EventRecorderConfirmationRecorder
+	)
+
+	BeforeEach(func() {
+		fakeRecorder = record.NewFakeRecorder(2)
+
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		recorder = &EventRecorderConfirmationRecorder{Recorder: fakeRecorder, Scheme: scheme}
+	})
+
+	Describe("#RecordConfirmation", func() {
+		It("resolves the kind via the scheme for a typed object with empty TypeMeta", func() {
+			configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", ResourceVersion: "2"}}
+
+			before := testutil.ToFloat64(DeletionConfirmationsTotal.WithLabelValues("ConfigMap", "success"))
+			recorder.RecordConfirmation(ctx, configMap, "")
+			Expect(testutil.ToFloat64(DeletionConfirmationsTotal.WithLabelValues("ConfigMap", "success"))).To(Equal(before + 1))
+
+			Expect(fakeRecorder.Events).To(Receive(ContainSubstring("DeletionConfirmed")))
+		})
+
+		It("uses the Kind already set on an unstructured object instead of consulting the scheme", func() {
+			obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			obj.SetGroupVersionKind(schema.GroupVersionKind{Group: "extensions.gardener.cloud", Version: "v1alpha1", Kind: "Network"})
+			obj.SetName("test")
+			obj.SetNamespace("default")
+
+			before := testutil.ToFloat64(DeletionConfirmationsTotal.WithLabelValues("Network", "success"))
+			recorder.RecordConfirmation(ctx, obj, "")
+			Expect(testutil.ToFloat64(DeletionConfirmationsTotal.WithLabelValues("Network", "success"))).To(Equal(before + 1))
+
+			Expect(fakeRecorder.Events).To(Receive(ContainSubstring("DeletionConfirmed")))
+		})
+	})
+
+	Describe("#RecordFailure", func() {
+		It("resolves the kind via the scheme and increments the error counter", func() {
+			configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"}}
+
+			before := testutil.ToFloat64(DeletionConfirmationsTotal.WithLabelValues("ConfigMap", "error"))
+			recorder.RecordFailure(ctx, configMap, fmt.Errorf("patch rejected"))
+			Expect(testutil.ToFloat64(DeletionConfirmationsTotal.WithLabelValues("ConfigMap", "error"))).To(Equal(before + 1))
+
+			Expect(fakeRecorder.Events).To(Receive(ContainSubstring("DeletionConfirmationFailed")))
+		})
+	})
+})
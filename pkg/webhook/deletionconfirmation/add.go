@@ -0,0 +1,91 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deletionconfirmation
+
+import (
+	"context"
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// AddToManager registers the deletion-confirmation validating webhook with mgr's webhook server. It protects
+// DefaultGroupVersionKinds plus every CustomResourceDefinition labeled DeletionProtectedLabel=true.
+func AddToManager(ctx context.Context, mgr manager.Manager) error {
+	crds, err := labeledCustomResourceDefinitions(ctx, mgr.GetAPIReader())
+	if err != nil {
+		return fmt.Errorf("failed determining deletion-protected CustomResourceDefinitions: %w", err)
+	}
+
+	handler := &Handler{
+		Client:            mgr.GetClient(),
+		GroupVersionKinds: append(append([]schema.GroupVersionKind{}, DefaultGroupVersionKinds...), groupVersionKindsOf(crds)...),
+	}
+
+	mgr.GetWebhookServer().Register(WebhookPath, &webhook.Admission{Handler: handler})
+	return nil
+}
+
+// LabeledGroupVersionResources returns the GroupVersionResource of every served version of every
+// CustomResourceDefinition labeled DeletionProtectedLabel=true. Together with DefaultGroupVersionResources, it is
+// the resource set GenerateValidatingWebhookConfiguration needs so that DELETE requests against those CRDs are
+// actually routed to this package's webhook, not just something Handler.protects would have accepted.
+func LabeledGroupVersionResources(ctx context.Context, c client.Reader) ([]schema.GroupVersionResource, error) {
+	crds, err := labeledCustomResourceDefinitions(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	return groupVersionResourcesOf(crds), nil
+}
+
+// labeledCustomResourceDefinitions returns every CustomResourceDefinition labeled DeletionProtectedLabel=true.
+func labeledCustomResourceDefinitions(ctx context.Context, c client.Reader) ([]apiextensionsv1.CustomResourceDefinition, error) {
+	crdList := &apiextensionsv1.CustomResourceDefinitionList{}
+	if err := c.List(ctx, crdList, client.MatchingLabelsSelector{Selector: labels.SelectorFromSet(labels.Set{DeletionProtectedLabel: "true"})}); err != nil {
+		return nil, err
+	}
+	return crdList.Items, nil
+}
+
+// groupVersionKindsOf returns the GroupVersionKind of every served version of crds.
+func groupVersionKindsOf(crds []apiextensionsv1.CustomResourceDefinition) []schema.GroupVersionKind {
+	var gvks []schema.GroupVersionKind
+	for _, crd := range crds {
+		for _, version := range crd.Spec.Versions {
+			if version.Served {
+				gvks = append(gvks, schema.GroupVersionKind{Group: crd.Spec.Group, Version: version.Name, Kind: crd.Spec.Names.Kind})
+			}
+		}
+	}
+	return gvks
+}
+
+// groupVersionResourcesOf returns the GroupVersionResource of every served version of crds.
+func groupVersionResourcesOf(crds []apiextensionsv1.CustomResourceDefinition) []schema.GroupVersionResource {
+	var gvrs []schema.GroupVersionResource
+	for _, crd := range crds {
+		for _, version := range crd.Spec.Versions {
+			if version.Served {
+				gvrs = append(gvrs, schema.GroupVersionResource{Group: crd.Spec.Group, Version: version.Name, Resource: crd.Spec.Names.Plural})
+			}
+		}
+	}
+	return gvrs
+}
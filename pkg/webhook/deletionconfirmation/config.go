@@ -0,0 +1,50 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package deletionconfirmation contains a validating admission webhook that enforces Gardener's deletion
+// confirmation annotation (see gardenerutils.CheckIfDeletionIsConfirmed) on a configurable set of
+// GroupVersionKinds before a DELETE request is allowed to proceed.
+package deletionconfirmation
+
+import (
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// Name is the name this webhook is registered under.
+	Name = "deletion-confirmation"
+	// WebhookPath is the HTTP path the webhook is served under.
+	WebhookPath = "/webhooks/validate-deletion-confirmation"
+	// DeletionProtectedLabel marks CustomResourceDefinitions whose instances are additionally protected by this
+	// webhook, on top of DefaultGroupVersionKinds.
+	DeletionProtectedLabel = "gardener.cloud/deletion-protected"
+)
+
+// DefaultGroupVersionKinds are the extension kinds protected by this webhook out of the box.
+var DefaultGroupVersionKinds = []schema.GroupVersionKind{
+	extensionsv1alpha1.SchemeGroupVersion.WithKind(extensionsv1alpha1.NetworkResource),
+	extensionsv1alpha1.SchemeGroupVersion.WithKind(extensionsv1alpha1.InfrastructureResource),
+	extensionsv1alpha1.SchemeGroupVersion.WithKind(extensionsv1alpha1.WorkerResource),
+	extensionsv1alpha1.SchemeGroupVersion.WithKind(extensionsv1alpha1.ControlPlaneResource),
+}
+
+// DefaultGroupVersionResources are the RESTful resources backing DefaultGroupVersionKinds, in the shape
+// GenerateValidatingWebhookConfiguration needs to build the installed ValidatingWebhookConfiguration's Rules.
+var DefaultGroupVersionResources = []schema.GroupVersionResource{
+	extensionsv1alpha1.SchemeGroupVersion.WithResource("networks"),
+	extensionsv1alpha1.SchemeGroupVersion.WithResource("infrastructures"),
+	extensionsv1alpha1.SchemeGroupVersion.WithResource("workers"),
+	extensionsv1alpha1.SchemeGroupVersion.WithResource("controlplanes"),
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deletionconfirmation
+
+import (
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
+)
+
+// GenerateValidatingWebhookConfiguration renders the ValidatingWebhookConfiguration that registers this package's
+// Handler with the API server. resources is the full set of GroupVersionResources DELETE requests must be routed
+// to the webhook for - typically DefaultGroupVersionResources plus whatever LabeledGroupVersionResources
+// discovers at install time, so that CustomResourceDefinitions labeled DeletionProtectedLabel=true are actually
+// forwarded to Handler rather than merely being something Handler.protects would have accepted had they arrived.
+// namespace/serviceName must point at the Service fronting the extension's webhook server, and caBundle is the
+// PEM-encoded CA used to validate its serving certificate.
+func GenerateValidatingWebhookConfiguration(namespace, serviceName string, caBundle []byte, resources []schema.GroupVersionResource) *admissionregistrationv1.ValidatingWebhookConfiguration {
+	sideEffects := admissionregistrationv1.SideEffectClassNone
+	failurePolicy := admissionregistrationv1.Fail
+	matchPolicy := admissionregistrationv1.Exact
+
+	rules := make([]admissionregistrationv1.RuleWithOperations, 0, len(resources))
+	for _, resource := range resources {
+		rules = append(rules, admissionregistrationv1.RuleWithOperations{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Delete},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{resource.Group},
+				APIVersions: []string{resource.Version},
+				Resources:   []string{resource.Resource},
+			},
+		})
+	}
+
+	return &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: Name,
+		},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+			Name:                    "deletion-confirmation.extensions.gardener.cloud",
+			AdmissionReviewVersions: []string{"v1"},
+			SideEffects:             &sideEffects,
+			FailurePolicy:           &failurePolicy,
+			MatchPolicy:             &matchPolicy,
+			Rules:                   rules,
+			ClientConfig: admissionregistrationv1.WebhookClientConfig{
+				CABundle: caBundle,
+				Service: &admissionregistrationv1.ServiceReference{
+					Namespace: namespace,
+					Name:      serviceName,
+					Path:      pointer.String(WebhookPath),
+				},
+			},
+		}},
+	}
+}
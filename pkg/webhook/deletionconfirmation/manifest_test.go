@@ -0,0 +1,49 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deletionconfirmation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	. "github.com/gardener/gardener-extension-networking-cilium/pkg/webhook/deletionconfirmation"
+)
+
+var _ = Describe("#GenerateValidatingWebhookConfiguration", func() {
+	It("emits one DELETE rule per resource, including labeled CRDs beyond the defaults", func() {
+		resources := append(append([]schema.GroupVersionResource{}, DefaultGroupVersionResources...),
+			schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"})
+
+		config := GenerateValidatingWebhookConfiguration("garden", "webhook-service", []byte("ca-bundle"), resources)
+
+		Expect(config.Webhooks).To(HaveLen(1))
+		Expect(config.Webhooks[0].Rules).To(HaveLen(len(resources)))
+
+		for i, resource := range resources {
+			rule := config.Webhooks[0].Rules[i]
+			Expect(rule.Operations).To(ConsistOf(admissionregistrationv1.Delete))
+			Expect(rule.APIGroups).To(ConsistOf(resource.Group))
+			Expect(rule.APIVersions).To(ConsistOf(resource.Version))
+			Expect(rule.Resources).To(ConsistOf(resource.Resource))
+		}
+
+		Expect(config.Webhooks[0].ClientConfig.Service.Namespace).To(Equal("garden"))
+		Expect(config.Webhooks[0].ClientConfig.Service.Name).To(Equal("webhook-service"))
+		Expect(config.Webhooks[0].ClientConfig.CABundle).To(Equal([]byte("ca-bundle")))
+	})
+})
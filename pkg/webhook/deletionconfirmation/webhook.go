@@ -0,0 +1,123 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deletionconfirmation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	gardenerutils "github.com/gardener/gardener/pkg/utils/gardener"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Handler is a validating admission webhook handler that denies DELETE requests for objects of the configured
+// GroupVersionKinds unless they carry the gardenerutils.ConfirmationDeletion annotation.
+type Handler struct {
+	// Client is used to look up the object being deleted if the API server did not populate req.OldObject, and
+	// to enumerate candidates for a DELETECOLLECTION request. It is typically the manager's cached client.
+	Client client.Reader
+	// GroupVersionKinds are the kinds this handler enforces the confirmation annotation for.
+	GroupVersionKinds []schema.GroupVersionKind
+}
+
+var _ admission.Handler = &Handler{}
+
+// Handle implements admission.Handler.
+func (h *Handler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Delete {
+		return admission.Allowed("")
+	}
+
+	gvk := schema.GroupVersionKind{Group: req.Kind.Group, Version: req.Kind.Version, Kind: req.Kind.Kind}
+	if !h.protects(gvk) {
+		return admission.Allowed("")
+	}
+
+	if req.Name == "" {
+		return h.handleDeleteCollection(ctx, req, gvk)
+	}
+
+	obj := &unstructured.Unstructured{}
+	if len(req.OldObject.Raw) > 0 {
+		if err := json.Unmarshal(req.OldObject.Raw, obj); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	} else {
+		obj.SetGroupVersionKind(gvk)
+		if err := h.Client.Get(ctx, client.ObjectKey{Namespace: req.Namespace, Name: req.Name}, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				return admission.Allowed("")
+			}
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+	}
+
+	return deny(gardenerutils.CheckIfDeletionIsConfirmed(obj))
+}
+
+// handleDeleteCollection validates every object that a DELETECOLLECTION request would remove and denies the
+// whole request if any of them is missing the confirmation annotation.
+func (h *Handler) handleDeleteCollection(ctx context.Context, req admission.Request, gvk schema.GroupVersionKind) admission.Response {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind + "List"})
+
+	if err := h.Client.List(ctx, list, client.InNamespace(req.Namespace)); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	for i := range list.Items {
+		if resp := deny(gardenerutils.CheckIfDeletionIsConfirmed(&list.Items[i])); !resp.Allowed {
+			return resp
+		}
+	}
+
+	return admission.Allowed("")
+}
+
+func (h *Handler) protects(gvk schema.GroupVersionKind) bool {
+	for _, candidate := range h.GroupVersionKinds {
+		if candidate == gvk {
+			return true
+		}
+	}
+	return false
+}
+
+// deny translates the error returned by gardenerutils.CheckIfDeletionIsConfirmed into an admission.Response that
+// points callers at the missing annotation via Status.Details.Causes.
+func deny(err error) admission.Response {
+	if err == nil {
+		return admission.Allowed("")
+	}
+
+	resp := admission.Denied(err.Error())
+	resp.Result.Details = &metav1.StatusDetails{
+		Causes: []metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldValueRequired,
+			Message: err.Error(),
+			Field:   fmt.Sprintf("metadata.annotations[%s]", gardenerutils.ConfirmationDeletion),
+		}},
+	}
+	return resp
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2021 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package deletionconfirmation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	gardenerutils "github.com/gardener/gardener/pkg/utils/gardener"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Handler", func() {
+	var network *extensionsv1alpha1.Network
+
+	BeforeEach(func() {
+		network = &extensionsv1alpha1.Network{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    "default",
+			},
+			Spec: extensionsv1alpha1.NetworkSpec{
+				Type: "cilium",
+			},
+		}
+		Expect(testClient.Create(ctx, network)).To(Succeed())
+	})
+
+	It("denies DELETE without the confirmation annotation", func() {
+		err := testClient.Delete(ctx, network)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(gardenerutils.ConfirmationDeletion))
+	})
+
+	It("allows DELETE once the confirmation annotation is set", func() {
+		Expect(gardenerutils.ConfirmDeletion(ctx, testClient, network)).To(Succeed())
+		Expect(testClient.Delete(ctx, network)).To(Succeed())
+	})
+
+	It("denies DELETECOLLECTION if any matching object lacks the confirmation annotation", func() {
+		other := &extensionsv1alpha1.Network{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "test-",
+				Namespace:    "default",
+			},
+			Spec: extensionsv1alpha1.NetworkSpec{
+				Type: "cilium",
+			},
+		}
+		Expect(testClient.Create(ctx, other)).To(Succeed())
+		Expect(gardenerutils.ConfirmDeletion(ctx, testClient, network)).To(Succeed())
+
+		err := testClient.DeleteAllOf(ctx, &extensionsv1alpha1.Network{}, client.InNamespace("default"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(gardenerutils.ConfirmationDeletion))
+	})
+
+	It("allows DELETECOLLECTION once every matching object carries the confirmation annotation", func() {
+		// Earlier specs leave unconfirmed Networks behind in the namespace, so confirm every one of them.
+		networkList := &extensionsv1alpha1.NetworkList{}
+		Expect(testClient.List(ctx, networkList, client.InNamespace("default"))).To(Succeed())
+		for i := range networkList.Items {
+			Expect(gardenerutils.ConfirmDeletion(ctx, testClient, &networkList.Items[i])).To(Succeed())
+		}
+
+		Expect(testClient.DeleteAllOf(ctx, &extensionsv1alpha1.Network{}, client.InNamespace("default"))).To(Succeed())
+	})
+})